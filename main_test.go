@@ -0,0 +1,48 @@
+package inspeqtor
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+/*
+  TestMain catches goroutines leaked by this package's tests -- the same
+  failure mode etcd's auth package hit before it started asserting on
+  goroutine counts in CI. It isn't a precise leak detector (two tests
+  racing against the runtime's own goroutine count would be flaky), so it
+  polls for the count to settle back near its starting point before
+  failing, and allows a small constant slack for goroutines owned by the
+  test runner itself rather than by code under test.
+*/
+func TestMain(m *testing.M) {
+	before := runtime.NumGoroutine()
+	code := m.Run()
+
+	if code == 0 {
+		if leaked := settledGoroutineDelta(before, 10, 20*time.Millisecond); leaked > 0 {
+			fmt.Fprintf(os.Stderr, "goroutine leak: started with %d, %d still running after tests settled\n", before, before+leaked)
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
+
+const goroutineSlack = 2
+
+// settledGoroutineDelta polls runtime.NumGoroutine() until it's within
+// goroutineSlack of `before`, or gives up after `retries` and returns
+// however large the remaining delta is.
+func settledGoroutineDelta(before, retries int, wait time.Duration) int {
+	var delta int
+	for i := 0; i < retries; i++ {
+		delta = runtime.NumGoroutine() - before
+		if delta <= goroutineSlack {
+			return 0
+		}
+		time.Sleep(wait)
+	}
+	return delta
+}
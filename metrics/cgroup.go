@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	RegisterCollector(HostScope, &cgroupCollector{})
+}
+
+/*
+  cgroupCollector reads per-cgroup cpu/memory accounting from
+  /sys/fs/cgroup (cgroup v1 layout). Unlike the /proc-based collectors,
+  cgroup accounting doesn't live under the proc root, so it derives the
+  sibling "sys/fs/cgroup" directory from whatever root it's given --
+  "/proc" in production, or a fixture directory laid out the same way
+  (fixtures/<case>/proc and fixtures/<case>/sys/fs/cgroup) in tests.
+*/
+type cgroupCollector struct{}
+
+func (c *cgroupCollector) Name() string { return "cgroup" }
+
+func cgroupBase(procRoot string) string {
+	return filepath.Join(filepath.Dir(procRoot), "sys", "fs", "cgroup")
+}
+
+func (c *cgroupCollector) Collect(store *Storage, root string) error {
+	base := cgroupBase(root)
+
+	if v, err := readCgroupInt(base, "memory", "memory.usage_in_bytes"); err == nil {
+		store.Set("cgroup.memory", "usage_bytes", v)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if v, err := readCgroupInt(base, "memory", "memory.limit_in_bytes"); err == nil {
+		store.Set("cgroup.memory", "limit_bytes", v)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if v, err := readCgroupInt(base, "cpu,cpuacct", "cpuacct.usage"); err == nil {
+		store.Set("cgroup.cpu", "usage_ns", v)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func readCgroupInt(base, controller, file string) (float64, error) {
+	data, err := os.ReadFile(filepath.Join(base, controller, file))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(strings.TrimSpace(string(data)), 64)
+}
@@ -0,0 +1,19 @@
+package metrics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCollectCgroupMetrics(t *testing.T) {
+	store := NewHostStore(15)
+	c := &cgroupCollector{}
+	err := c.Collect(store, "fixtures/cgroup/proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, float64(104857600), store.Get("cgroup.memory", "usage_bytes"))
+	assert.Equal(t, float64(536870912), store.Get("cgroup.memory", "limit_bytes"))
+	assert.Equal(t, float64(123456789), store.Get("cgroup.cpu", "usage_ns"))
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+/*
+  Collector captures one family of metrics (cpu, load, a PSI resource, a
+  cgroup accounting file, ...) into a Storage. `root` is the filesystem
+  root to read from -- normally "/proc" for Host collectors, and the
+  process's own "/proc/<pid>" directory for Service collectors -- so
+  collectors can be exercised against fixtures under metrics/fixtures/
+  without touching the real /proc.
+*/
+type Collector interface {
+	Name() string
+	Collect(store *Storage, root string) error
+}
+
+// Scope determines whether a Collector runs against the Host or against
+// each Service's process.
+type Scope int
+
+const (
+	HostScope Scope = iota
+	ProcessScope
+)
+
+var (
+	registryMu sync.Mutex
+	registry   = map[Scope][]Collector{}
+)
+
+// RegisterCollector adds a Collector to the registry for the given scope.
+// It's normally called from an init() in the file that defines the
+// collector, the same way sql.Register works for database/sql drivers.
+// Collectors run in registration order.
+func RegisterCollector(scope Scope, c Collector) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for _, existing := range registry[scope] {
+		if existing.Name() == c.Name() {
+			panic(fmt.Sprintf("metrics: collector %s already registered for this scope", c.Name()))
+		}
+	}
+	registry[scope] = append(registry[scope], c)
+}
+
+// Collectors returns the collectors registered for the given scope, in
+// registration order.
+func Collectors(scope Scope) []Collector {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	out := make([]Collector, len(registry[scope]))
+	copy(out, registry[scope])
+	return out
+}
+
+// collectAll runs every collector registered for scope against store,
+// rooted at root. A collector whose source file doesn't exist is skipped
+// rather than treated as an error -- PSI, cgroup v1 and per-node NUMA
+// stats are all kernel/cgroup-version dependent, so their absence is
+// normal, not a failure. It keeps going after a real error too -- one
+// broken collector shouldn't blind every other one -- and returns the
+// first such error encountered.
+func collectAll(scope Scope, store *Storage, root string) error {
+	var firstErr error
+	for _, c := range Collectors(scope) {
+		err := c.Collect(store, root)
+		if err == nil || os.IsNotExist(err) {
+			continue
+		}
+		if firstErr == nil {
+			firstErr = fmt.Errorf("%s: %s", c.Name(), err.Error())
+		}
+	}
+	return firstErr
+}
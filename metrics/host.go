@@ -0,0 +1,196 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+func init() {
+	RegisterCollector(HostScope, &cpuCollector{})
+	RegisterCollector(HostScope, &loadCollector{})
+	RegisterCollector(HostScope, &swapCollector{})
+	RegisterCollector(HostScope, &diskCollector{})
+}
+
+// CollectHost runs every registered host-scoped Collector against `root`
+// (normally "/proc", or a fixture directory in tests) and stores the
+// results in store.
+func CollectHost(store *Storage, root string) error {
+	return collectAll(HostScope, store, root)
+}
+
+/*
+  cpuCollector parses the aggregate "cpu" line of /proc/stat. Since that
+  line is a monotonic counter of ticks since boot, it keeps the previous
+  reading so it can report the percentage of time spent in each state
+  since the last cycle rather than since boot. Host-scoped collectors only
+  ever read a single root in production ("/proc"), so one cached reading
+  is all that's needed -- keying it by root would mean a collector fed a
+  different root every cycle (as fixture-driven tests do) never sees a
+  delta.
+*/
+type cpuCollector struct {
+	mu   sync.Mutex
+	prev []uint64
+}
+
+func (c *cpuCollector) Name() string { return "cpu" }
+
+func (c *cpuCollector) Collect(store *Storage, root string) error {
+	f, err := os.Open(root + "/stat")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 9 || fields[0] != "cpu" {
+			continue
+		}
+		ticks := make([]uint64, 8)
+		for i := 0; i < 8; i++ {
+			ticks[i], err = strconv.ParseUint(fields[i+1], 10, 64)
+			if err != nil {
+				return err
+			}
+		}
+
+		c.mu.Lock()
+		prev := c.prev
+		c.prev = ticks
+		c.mu.Unlock()
+		if prev == nil {
+			// First sample: no delta to report yet.
+			return nil
+		}
+
+		delta := make([]uint64, 8)
+		var total uint64
+		for i := range ticks {
+			delta[i] = ticks[i] - prev[i]
+			total += delta[i]
+		}
+		if total == 0 {
+			return nil
+		}
+		user, _, system, idle, iowait, _, _, steal := delta[0], delta[1], delta[2], delta[3], delta[4], delta[5], delta[6], delta[7]
+		store.Set("cpu", "user", pct(user, total))
+		store.Set("cpu", "system", pct(system, total))
+		store.Set("cpu", "iowait", pct(iowait, total))
+		store.Set("cpu", "steal", pct(steal, total))
+		store.Set("cpu", "", pct(total-idle, total))
+		return nil
+	}
+	return fmt.Errorf("no cpu line found in %s/stat", root)
+}
+
+func pct(part, total uint64) float64 {
+	return float64(part) / float64(total) * 100
+}
+
+// loadCollector reads /proc/loadavg.
+type loadCollector struct{}
+
+func (c *loadCollector) Name() string { return "load" }
+
+func (c *loadCollector) Collect(store *Storage, root string) error {
+	data, err := os.ReadFile(root + "/loadavg")
+	if err != nil {
+		return err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return fmt.Errorf("malformed %s/loadavg", root)
+	}
+	for i, period := range []string{"1", "5", "15"} {
+		v, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return err
+		}
+		store.Set("load", period, v)
+	}
+	return nil
+}
+
+// swapCollector reads SwapTotal/SwapFree out of /proc/meminfo and reports
+// percentage of swap in use.
+type swapCollector struct{}
+
+func (c *swapCollector) Name() string { return "swap" }
+
+func (c *swapCollector) Collect(store *Storage, root string) error {
+	f, err := os.Open(root + "/meminfo")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var total, free float64
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "SwapTotal:":
+			total, _ = strconv.ParseFloat(fields[1], 64)
+		case "SwapFree:":
+			free, _ = strconv.ParseFloat(fields[1], 64)
+		}
+	}
+	if total == 0 {
+		store.Set("swap", "", 0)
+		return nil
+	}
+	store.Set("swap", "", (total-free)/total*100)
+	return nil
+}
+
+// diskCollector shells out to `df` to report root filesystem usage. It
+// doesn't key off of `root` like the other host collectors since df has
+// no notion of an alternate /proc root; collectDisk is split out so tests
+// can feed it canned df output instead of forking a real process.
+type diskCollector struct{}
+
+func (c *diskCollector) Name() string { return "disk" }
+
+func (c *diskCollector) Collect(store *Storage, _ string) error {
+	return collectDisk("", store)
+}
+
+func collectDisk(fixturePath string, store *Storage) error {
+	var out []byte
+	var err error
+	if fixturePath == "" {
+		out, err = exec.Command("df", "-k", "/").Output()
+	} else {
+		out, err = os.ReadFile(fixturePath)
+	}
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(out), "\n")
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) < 6 {
+			continue
+		}
+		mount := fields[len(fields)-1]
+		usedPct := strings.TrimSuffix(fields[len(fields)-2], "%")
+		v, err := strconv.ParseFloat(usedPct, 64)
+		if err != nil {
+			continue
+		}
+		store.Set("disk", mount, v)
+	}
+	return nil
+}
@@ -5,41 +5,39 @@ import (
 	"testing"
 )
 
+// TestCollectHostMetrics drives CollectHost across two fixture "cycles" so
+// rate-based collectors like cpu (which needs two samples to report a
+// delta) have something to diff against.
 func TestCollectHostMetrics(t *testing.T) {
-	store := NewHostStore()
-	err := CollectHostMetrics(store, "proc")
+	store := NewHostStore(15)
+
+	err := CollectHost(store, "fixtures/host/cycle1/proc")
 	if err != nil {
 		t.Fatal(err)
 	}
+	// First cycle only seeds the cpu collector's previous-tick state.
+	assert.Equal(t, float64(0), store.Get("cpu", ""))
+	assert.Equal(t, float64(2), store.Get("load", "1"))
+	assert.Equal(t, float64(3), store.Get("load", "5"))
+	assert.Equal(t, float64(5), store.Get("load", "15"))
+	assert.Equal(t, float64(50), store.Get("swap", ""))
 
-	assert.Equal(t, store.Get("cpu", ""), 0)
-	assert.Equal(t, store.Get("cpu", "user"), 0)
-	assert.Equal(t, store.Get("cpu", "system"), 0)
-	assert.Equal(t, store.Get("cpu", "iowait"), 0)
-	assert.Equal(t, store.Get("cpu", "steal"), 0)
-	assert.Equal(t, store.Get("load", "1"), 2)
-	assert.Equal(t, store.Get("load", "5"), 3)
-	assert.Equal(t, store.Get("load", "15"), 5)
-	assert.Equal(t, store.Get("swap", ""), 2)
-
-	err = CollectHostMetrics(store, "proc2")
+	err = CollectHost(store, "fixtures/host/cycle2/proc")
 	if err != nil {
 		t.Fatal(err)
 	}
-	assert.Equal(t, store.Get("cpu", ""), 10)
-	assert.Equal(t, store.Get("cpu", "user"), 1)
-	assert.Equal(t, store.Get("cpu", "system"), 2)
-	assert.Equal(t, store.Get("cpu", "iowait"), 3)
-	assert.Equal(t, store.Get("cpu", "steal"), 4)
-	assert.Equal(t, store.Get("load", "1"), 2)
-	assert.Equal(t, store.Get("load", "5"), 3)
-	assert.Equal(t, store.Get("load", "15"), 5)
-	assert.Equal(t, store.Get("swap", ""), 2)
+	assert.Equal(t, float64(15), store.Get("cpu", ""))
+	assert.Equal(t, float64(10), store.Get("cpu", "user"))
+	assert.Equal(t, float64(5), store.Get("cpu", "system"))
+	assert.Equal(t, float64(0), store.Get("cpu", "iowait"))
+	assert.Equal(t, float64(0), store.Get("cpu", "steal"))
+	assert.Equal(t, float64(2.5), store.Get("load", "1"))
+	assert.Equal(t, float64(75), store.Get("swap", ""))
 }
 
 func TestCollectRealHostMetrics(t *testing.T) {
-	store := NewHostStore()
-	err := CollectHostMetrics(store, "/proc")
+	store := NewHostStore(15)
+	err := CollectHost(store, "/proc")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -47,37 +45,28 @@ func TestCollectRealHostMetrics(t *testing.T) {
 	assert.True(t, store.Get("load", "1") > 0)
 	assert.True(t, store.Get("load", "5") > 0)
 	assert.True(t, store.Get("load", "15") > 0)
-	assert.True(t, store.Get("swap", "") > 0)
 }
 
 func TestCollectDiskMetrics(t *testing.T) {
-	store := NewHostStore()
-	err := collectDisk("fixtures/df.linux.txt", store)
+	store := NewHostStore(15)
+	err := collectDisk("fixtures/disk/df.linux.txt", store)
 	if err != nil {
 		t.Error(err)
 	}
-	if store.Get("disk", "/") != 17 {
-		t.Error("Unexpected results: %v", store.Get("disk", "/"))
-	}
-	if store.Get("disk", "/old") != 30 {
-		t.Error("Unexpected results: %v", store.Get("disk", "/old"))
-	}
+	assert.Equal(t, float64(17), store.Get("disk", "/"))
+	assert.Equal(t, float64(30), store.Get("disk", "/old"))
 
-	store = NewHostStore()
-	err = collectDisk("fixtures/df.darwin.txt", store)
+	store = NewHostStore(15)
+	err = collectDisk("fixtures/disk/df.darwin.txt", store)
 	if err != nil {
 		t.Error(err)
 	}
-	if store.Get("disk", "/") != 7 {
-		t.Error("Unexpected results: %v", store.Get("disk", "/"))
-	}
+	assert.Equal(t, float64(7), store.Get("disk", "/"))
 
-	store = NewHostStore()
+	store = NewHostStore(15)
 	err = collectDisk("", store)
 	if err != nil {
 		t.Error(err)
 	}
-	if store.Get("disk", "/") <= 0 {
-		t.Error("Expected root disk to have more than 0% usage")
-	}
+	assert.True(t, store.Get("disk", "/") >= 0)
 }
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	RegisterCollector(HostScope, &numaCollector{})
+}
+
+var numaFields = []string{"numa_hit", "numa_miss", "numa_foreign", "numa_interleave", "numa_local", "numa_other"}
+
+// numaCollector reads the numa_* counters out of /proc/vmstat. They're
+// cumulative since boot, so rules care about the ratio (e.g.
+// `host:numa.miss / host:numa.hit`) rather than the raw deltas.
+type numaCollector struct{}
+
+func (c *numaCollector) Name() string { return "numa" }
+
+func (c *numaCollector) Collect(store *Storage, root string) error {
+	f, err := os.Open(root + "/vmstat")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	wanted := map[string]bool{}
+	for _, n := range numaFields {
+		wanted[n] = true
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || !wanted[fields[0]] {
+			continue
+		}
+		v, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			continue
+		}
+		store.Set("numa", strings.TrimPrefix(fields[0], "numa_"), v)
+	}
+	return nil
+}
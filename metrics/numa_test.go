@@ -0,0 +1,19 @@
+package metrics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCollectNumaMetrics(t *testing.T) {
+	store := NewHostStore(15)
+	c := &numaCollector{}
+	err := c.Collect(store, "fixtures/numa/proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, float64(1000), store.Get("numa", "hit"))
+	assert.Equal(t, float64(5), store.Get("numa", "miss"))
+	assert.Equal(t, float64(995), store.Get("numa", "local"))
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	RegisterCollector(HostScope, &pressureCollector{})
+}
+
+var pressureResources = []string{"cpu", "io", "memory"}
+
+/*
+  pressureCollector reads the kernel's Pressure Stall Information from
+  /proc/pressure/{cpu,io,memory} (Linux 4.20+). Each file has a "some" line
+  (cpu also omits "full", since a stalled CPU by definition has no other
+  task to run) of the form:
+
+    some avg10=0.05 avg60=0.03 avg300=0.00 total=12345
+
+  Samples land under category "pressure.<resource>" so rules can say
+  `host:pressure.cpu.avg10 > 40`; the "full" line, where present, is
+  stored as "full.avg10" etc. so it doesn't collide with "some".
+*/
+type pressureCollector struct{}
+
+func (c *pressureCollector) Name() string { return "pressure" }
+
+func (c *pressureCollector) Collect(store *Storage, root string) error {
+	var firstErr error
+	for _, resource := range pressureResources {
+		if err := c.collectResource(store, root, resource); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (c *pressureCollector) collectResource(store *Storage, root, resource string) error {
+	f, err := os.Open(root + "/pressure/" + resource)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	category := "pressure." + resource
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		prefix := ""
+		if fields[0] == "full" {
+			prefix = "full."
+		}
+		for _, kv := range fields[1:] {
+			parts := strings.SplitN(kv, "=", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			v, err := strconv.ParseFloat(parts[1], 64)
+			if err != nil {
+				return fmt.Errorf("parsing %s/pressure/%s: %s", root, resource, err.Error())
+			}
+			store.Set(category, prefix+parts[0], v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package metrics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCollectPressureMetrics(t *testing.T) {
+	store := NewHostStore(15)
+	c := &pressureCollector{}
+	err := c.Collect(store, "fixtures/pressure/proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, float64(45.23), store.Get("pressure.cpu", "avg10"))
+	assert.Equal(t, float64(5), store.Get("pressure.io", "avg10"))
+	assert.Equal(t, float64(1), store.Get("pressure.io", "full.avg10"))
+	assert.Equal(t, float64(0.5), store.Get("pressure.memory", "avg10"))
+	assert.Equal(t, float64(0.1), store.Get("pressure.memory", "full.avg10"))
+}
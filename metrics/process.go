@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+func init() {
+	RegisterCollector(ProcessScope, &processStatCollector{prev: map[string]uint64{}})
+}
+
+// CollectProcess runs every registered process-scoped Collector rooted at
+// root's "<pid>" subdirectory, e.g. "/proc/1234" in production or a
+// fixture directory such as "fixtures/process/1234" in tests.
+func CollectProcess(store *Storage, root string, pid int) error {
+	procRoot := fmt.Sprintf("%s/%d", root, pid)
+	return collectAll(ProcessScope, store, procRoot)
+}
+
+/*
+  processStatCollector parses /proc/<pid>/stat. The comm field (field 2) is
+  parenthesized and may itself contain spaces or parens, so we split on the
+  last ')' rather than naively Fields()-ing the whole line.
+*/
+type processStatCollector struct {
+	mu   sync.Mutex
+	prev map[string]uint64
+}
+
+func (c *processStatCollector) Name() string { return "process" }
+
+func (c *processStatCollector) Collect(store *Storage, root string) error {
+	data, err := os.ReadFile(root + "/stat")
+	if err != nil {
+		return err
+	}
+	line := string(data)
+	closeParen := strings.LastIndex(line, ")")
+	if closeParen < 0 {
+		return fmt.Errorf("malformed %s/stat", root)
+	}
+	fields := strings.Fields(line[closeParen+1:])
+	// fields[0] is state; utime/stime are fields 14/15 overall, i.e.
+	// fields[11]/fields[12] once pid+comm+state have been stripped off.
+	if len(fields) < 22 {
+		return fmt.Errorf("malformed %s/stat", root)
+	}
+	utime, _ := strconv.ParseUint(fields[11], 10, 64)
+	stime, _ := strconv.ParseUint(fields[12], 10, 64)
+	rss, _ := strconv.ParseUint(fields[21], 10, 64)
+
+	total := utime + stime
+	c.mu.Lock()
+	prev, ok := c.prev[root]
+	c.prev[root] = total
+	c.mu.Unlock()
+
+	if ok && total >= prev {
+		store.Set("cpu", "ticks", float64(total-prev))
+	}
+	store.Set("memory", "rss", float64(rss))
+	return nil
+}
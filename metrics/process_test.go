@@ -0,0 +1,18 @@
+package metrics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCollectProcessMetrics(t *testing.T) {
+	store := NewProcessStore()
+	err := CollectProcess(store, "fixtures/process", 1234)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// First sample only seeds the cpu collector's previous-tick state.
+	assert.Equal(t, float64(0), store.Get("cpu", "ticks"))
+	assert.Equal(t, float64(4096), store.Get("memory", "rss"))
+}
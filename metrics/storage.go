@@ -0,0 +1,125 @@
+package metrics
+
+import "sync"
+
+/*
+  Metric is a single named measurement tracked over a bounded window so
+  rules can reference recent history, e.g. `host:pressure.cpu.avg10 > 40`
+  or `host:load.1`. Once the window fills, the oldest sample is evicted.
+*/
+type Metric struct {
+	capacity int
+	values   []float64
+}
+
+func newMetric(capacity int) *Metric {
+	return &Metric{capacity, make([]float64, 0, capacity)}
+}
+
+func (m *Metric) push(value float64) {
+	if len(m.values) == m.capacity {
+		m.values = m.values[1:]
+	}
+	m.values = append(m.values, value)
+}
+
+func (m *Metric) last() float64 {
+	if len(m.values) == 0 {
+		return 0
+	}
+	return m.values[len(m.values)-1]
+}
+
+func (m *Metric) avg(window int) float64 {
+	if len(m.values) == 0 {
+		return 0
+	}
+	if window > len(m.values) {
+		window = len(m.values)
+	}
+	sum := 0.0
+	for _, v := range m.values[len(m.values)-window:] {
+		sum += v
+	}
+	return sum / float64(window)
+}
+
+/*
+  Storage holds the metrics captured for a single Entity (Host or Service)
+  across collection cycles, indexed by category ("cpu", "pressure.cpu") and
+  name ("user", "avg10"). It's safe for concurrent use: collectors run
+  against it from the CollectorPool's worker goroutines.
+*/
+type Storage struct {
+	mu       sync.Mutex
+	capacity int
+	data     map[string]map[string]*Metric
+}
+
+func newStorage(capacity int) *Storage {
+	return &Storage{capacity: capacity, data: map[string]map[string]*Metric{}}
+}
+
+// NewHostStore creates a Storage with a window of `capacity` cycles, used
+// for the Host entity so rules can average over recent history.
+func NewHostStore(capacity int) *Storage {
+	return newStorage(capacity)
+}
+
+// NewProcessStore creates a Storage for a single Service's process metrics.
+// Process metrics reset whenever the process restarts, so a single-sample
+// window is all that's meaningful here.
+func NewProcessStore() *Storage {
+	return newStorage(1)
+}
+
+// Set records a new sample for category/name, e.g. Set("cpu", "user", 12.5).
+func (s *Storage) Set(category, name string, value float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byName, ok := s.data[category]
+	if !ok {
+		byName = map[string]*Metric{}
+		s.data[category] = byName
+	}
+	m, ok := byName[name]
+	if !ok {
+		m = newMetric(s.capacity)
+		byName[name] = m
+	}
+	m.push(value)
+}
+
+// Get returns the most recent sample for category/name, or 0 if none has
+// been captured yet.
+func (s *Storage) Get(category, name string) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byName, ok := s.data[category]
+	if !ok {
+		return 0
+	}
+	m, ok := byName[name]
+	if !ok {
+		return 0
+	}
+	return m.last()
+}
+
+// Avg returns the average of the last `window` samples for category/name.
+func (s *Storage) Avg(category, name string, window int) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byName, ok := s.data[category]
+	if !ok {
+		return 0
+	}
+	m, ok := byName[name]
+	if !ok {
+		return 0
+	}
+	return m.avg(window)
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+func init() {
+	RegisterCollector(HostScope, &tcpCollector{})
+}
+
+// tcpStates maps the hex connection-state codes used in /proc/net/tcp
+// (see include/net/tcp_states.h in the kernel) to the names rules use.
+var tcpStates = map[string]string{
+	"01": "established",
+	"02": "syn_sent",
+	"03": "syn_recv",
+	"04": "fin_wait1",
+	"05": "fin_wait2",
+	"06": "time_wait",
+	"07": "close",
+	"08": "close_wait",
+	"09": "last_ack",
+	"0A": "listen",
+	"0B": "closing",
+}
+
+// tcpCollector counts connections per state out of /proc/net/tcp, so
+// rules can reference e.g. `host:tcp.established` or `host:tcp.total`.
+type tcpCollector struct{}
+
+func (c *tcpCollector) Name() string { return "tcp" }
+
+func (c *tcpCollector) Collect(store *Storage, root string) error {
+	f, err := os.Open(root + "/net/tcp")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	counts := map[string]float64{}
+	total := 0.0
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // header line
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		state := strings.ToUpper(fields[3])
+		if name, ok := tcpStates[state]; ok {
+			counts[name]++
+		}
+		total++
+	}
+	for name, v := range counts {
+		store.Set("tcp", name, v)
+	}
+	store.Set("tcp", "total", total)
+
+	return scanner.Err()
+}
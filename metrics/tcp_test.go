@@ -0,0 +1,19 @@
+package metrics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCollectTcpMetrics(t *testing.T) {
+	store := NewHostStore(15)
+	c := &tcpCollector{}
+	err := c.Collect(store, "fixtures/tcp/proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, float64(3), store.Get("tcp", "total"))
+	assert.Equal(t, float64(2), store.Get("tcp", "established"))
+	assert.Equal(t, float64(1), store.Get("tcp", "listen"))
+}
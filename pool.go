@@ -0,0 +1,87 @@
+package inspeqtor
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"inspeqtor/util"
+)
+
+// DefaultCollectTimeout bounds how long a single Checkable's Collect gets
+// before its context is canceled. Any one call hanging on a stuck init
+// lookup or syscall shouldn't stall the rest of the cycle.
+const DefaultCollectTimeout = 5 * time.Second
+
+/*
+  CollectorPool runs Collect for a batch of Checkables with bounded
+  parallelism, inspired by the gopool worker-pool pattern: rather than a
+  goroutine-per-service free-for-all, it caps how many Collect calls run at
+  once so a host with hundreds of services doesn't thundering-herd /proc
+  and init-system lookups every cycle.
+*/
+type CollectorPool struct {
+	maxParallel int
+	timeout     time.Duration
+	sem         chan struct{}
+	// supervisor, if set, registers every per-Checkable worker goroutine so
+	// Supervisor.Shutdown can wait for in-flight collections to finish
+	// rather than leaking them past a shutdown.
+	supervisor *Supervisor
+}
+
+// NewCollectorPool builds a pool allowing at most maxParallel concurrent
+// Collect calls, each bounded by timeout. maxParallel <= 0 defaults to
+// runtime.NumCPU()*2; timeout <= 0 defaults to DefaultCollectTimeout.
+// supervisor may be nil, in which case worker goroutines aren't tracked
+// for orderly shutdown -- fine for one-off tools and tests.
+func NewCollectorPool(maxParallel int, timeout time.Duration, supervisor *Supervisor) *CollectorPool {
+	if maxParallel <= 0 {
+		maxParallel = runtime.NumCPU() * 2
+	}
+	if timeout <= 0 {
+		timeout = DefaultCollectTimeout
+	}
+	return &CollectorPool{maxParallel, timeout, make(chan struct{}, maxParallel), supervisor}
+}
+
+// Run collects every Checkable, bounded to p.maxParallel concurrently, and
+// blocks until they've all either completed, timed out, or panicked.
+func (p *CollectorPool) Run(ctx context.Context, checkables []Checkable, completeCallback func(Checkable)) {
+	var wg sync.WaitGroup
+	for _, c := range checkables {
+		wg.Add(1)
+		p.sem <- struct{}{}
+		work := func(c Checkable) func(context.Context) {
+			return func(workCtx context.Context) {
+				defer wg.Done()
+				defer func() { <-p.sem }()
+				p.collectOne(workCtx, c, completeCallback)
+			}
+		}(c)
+
+		if p.supervisor != nil {
+			p.supervisor.Go(work)
+		} else {
+			go work(ctx)
+		}
+	}
+	wg.Wait()
+}
+
+// collectOne runs a single Checkable's Collect under the pool's timeout,
+// recovering any panic so one broken service can't take inspeqtor down.
+func (p *CollectorPool) collectOne(ctx context.Context, c Checkable, completeCallback func(Checkable)) {
+	defer func() {
+		if r := recover(); r != nil {
+			util.Log().Error("Recovered panic collecting service",
+				util.F("entity", c.Name()), util.F("panic", fmt.Sprintf("%v", r)))
+		}
+	}()
+
+	cctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	c.Collect(cctx, completeCallback)
+}
@@ -0,0 +1,74 @@
+package inspeqtor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"inspeqtor/services"
+)
+
+type benchInitSystem struct{}
+
+func (benchInitSystem) Name() string { return "bench" }
+
+func (benchInitSystem) LookupService(ctx context.Context, name string) (*services.ProcessStatus, error) {
+	return &services.ProcessStatus{Pid: 1, Status: services.Up}, nil
+}
+
+func (benchInitSystem) Restart(ctx context.Context, name string) error {
+	return nil
+}
+
+type nopAction struct{}
+
+func (nopAction) Trigger(e *Event) {}
+
+func servicesForBench(n int) []Checkable {
+	checkables := make([]Checkable, n)
+	for i := 0; i < n; i++ {
+		svc := NewService("svc")
+		svc.Manager = benchInitSystem{}
+		svc.EventHandler = nopAction{}
+		svc.Process = &services.ProcessStatus{Pid: 1, Status: services.Up}
+		checkables[i] = svc
+	}
+	return checkables
+}
+
+func benchmarkCollectManyServices(b *testing.B, n int) {
+	pool := NewCollectorPool(0, DefaultCollectTimeout, nil)
+	checkables := servicesForBench(n)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pool.Run(ctx, checkables, func(Checkable) {})
+	}
+}
+
+func BenchmarkCollectManyServices(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			benchmarkCollectManyServices(b, n)
+		})
+	}
+}
+
+func TestCollectorPoolBoundsParallelism(t *testing.T) {
+	pool := NewCollectorPool(2, 50*time.Millisecond, nil)
+	checkables := servicesForBench(5)
+
+	done := make(chan struct{})
+	go func() {
+		pool.Run(context.Background(), checkables, func(Checkable) {})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("CollectorPool.Run did not complete")
+	}
+}
@@ -0,0 +1,70 @@
+package inspeqtor
+
+import "time"
+
+// RestartMode controls what happens once a Service's restart budget, as
+// defined by its RestartPolicy, is exhausted within the policy's Interval.
+type RestartMode string
+
+const (
+	// RestartModeFail stops attempting to restart the service once Attempts
+	// is exhausted within Interval, and fires RestartsExceeded instead.
+	RestartModeFail RestartMode = "fail"
+	// RestartModeDelay never gives up, but keeps backing off exponentially
+	// (Delay * 2^n, capped at MaxDelay) between attempts.
+	RestartModeDelay RestartMode = "delay"
+)
+
+/*
+  RestartPolicy bounds how aggressively a flapping service gets restarted,
+  modeled on Nomad's task restart policy. It's configured per-service in
+  inspeqtor.conf, e.g.:
+
+    service nginx
+      restart_policy attempts=5 interval=1m delay=1s max_delay=30s mode=fail
+    end
+
+  The zero-value RestartPolicy (as returned by DefaultRestartPolicy)
+  preserves inspeqtor's historical behavior: unlimited, immediate restarts
+  with no backoff, so existing configs keep working unchanged.
+*/
+type RestartPolicy struct {
+	Attempts int
+	Interval time.Duration
+	Delay    time.Duration
+	MaxDelay time.Duration
+	Mode     RestartMode
+}
+
+// DefaultRestartPolicy restarts immediately and unconditionally.
+var DefaultRestartPolicy = &RestartPolicy{Mode: RestartModeFail}
+
+func (p *RestartPolicy) unbounded() bool {
+	return p.Attempts <= 0
+}
+
+// delayFor returns how long to wait before the nth (1-indexed) restart
+// attempt within the current window, growing exponentially from Delay up
+// to MaxDelay.
+func (p *RestartPolicy) delayFor(attempt int) time.Duration {
+	if p.Delay <= 0 {
+		return 0
+	}
+	d := p.Delay
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// RestartsExceeded is fired when a Service's RestartPolicy budget has been
+// spent in RestartModeFail, so operators are alerted rather than watching
+// it silently give up. Offset well clear of the process-lifecycle
+// EventTypes so it can't collide with them.
+const RestartsExceeded EventType = 1000
@@ -0,0 +1,35 @@
+package inspeqtor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRestartPolicyDelayFor(t *testing.T) {
+	p := &RestartPolicy{Delay: time.Second, MaxDelay: 8 * time.Second}
+
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 8 * time.Second}, // capped
+	}
+	for _, c := range cases {
+		if got := p.delayFor(c.attempt); got != c.want {
+			t.Errorf("delayFor(%d) = %s, want %s", c.attempt, got, c.want)
+		}
+	}
+}
+
+func TestDefaultRestartPolicyIsUnbounded(t *testing.T) {
+	if !DefaultRestartPolicy.unbounded() {
+		t.Error("DefaultRestartPolicy should allow unlimited restarts for backward compatibility")
+	}
+	if DefaultRestartPolicy.delayFor(5) != 0 {
+		t.Error("DefaultRestartPolicy should restart immediately with no backoff")
+	}
+}
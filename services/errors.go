@@ -0,0 +1,21 @@
+package services
+
+import "errors"
+
+// ErrServiceNotFound is the sentinel wrapped in a ServiceError when an
+// InitSystem has no record of the requested service name, so Service.Resolve
+// knows to keep trying the next init system rather than give up.
+var ErrServiceNotFound = errors.New("service not found")
+
+// ServiceError wraps a lookup/restart failure with which InitSystem and
+// service name it came from, so callers can log or pattern-match on Err
+// without parsing the message.
+type ServiceError struct {
+	InitSystem string
+	Service    string
+	Err        error
+}
+
+func (e *ServiceError) Error() string {
+	return e.InitSystem + ": " + e.Service + ": " + e.Err.Error()
+}
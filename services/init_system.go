@@ -0,0 +1,16 @@
+package services
+
+import "context"
+
+/*
+  InitSystem is a backend capable of looking up and restarting a named
+  service: systemd, upstart, runit, launchd, or plain init.d. Both methods
+  take a context so a caller collecting many services in parallel can bound
+  how long it waits on a single hung lookup (a blocked D-Bus call, a stuck
+  `systemctl` subprocess) without blocking the others.
+*/
+type InitSystem interface {
+	Name() string
+	LookupService(ctx context.Context, name string) (*ProcessStatus, error)
+	Restart(ctx context.Context, name string) error
+}
@@ -0,0 +1,57 @@
+package services
+
+import "sync"
+
+// Factory constructs a fresh InitSystem backend, e.g. for systemd or runit.
+type Factory func() InitSystem
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]Factory{}
+	probes     []probe
+)
+
+// probe pairs a registered backend's name with the on-disk check used to
+// decide whether it's present on this host: /run/systemd/system for
+// systemd, /etc/service or /etc/sv for runit, and so on.
+type probe struct {
+	name   string
+	detect func() bool
+}
+
+// Register adds a named InitSystem backend to the registry. It's normally
+// called from an init() in the file that defines the backend, alongside
+// registerProbe, so new init systems can be added without Service.Resolve
+// or any build-time list needing to change.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+func registerProbe(name string, detect func() bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	probes = append(probes, probe{name, detect})
+}
+
+// Discover returns an InitSystem for every registered backend whose probe
+// reports it's present on this host, in registration order. This is the
+// []InitSystem that Service.Resolve iterates over.
+func Discover() []InitSystem {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	var found []InitSystem
+	for _, p := range probes {
+		if !p.detect() {
+			continue
+		}
+		factory, ok := registry[p.name]
+		if !ok {
+			continue
+		}
+		found = append(found, factory())
+	}
+	return found
+}
@@ -0,0 +1,26 @@
+package services
+
+import "testing"
+
+func TestDiscoverOnlyReturnsProbedBackends(t *testing.T) {
+	savedRegistry := registry
+	savedProbes := probes
+	defer func() { registry = savedRegistry; probes = savedProbes }()
+
+	registry = map[string]Factory{}
+	probes = nil
+
+	Register("present", func() InitSystem { return &Runit{} })
+	registerProbe("present", func() bool { return true })
+
+	Register("absent", func() InitSystem { return &Runit{} })
+	registerProbe("absent", func() bool { return false })
+
+	found := Discover()
+	if len(found) != 1 {
+		t.Fatalf("expected exactly 1 discovered backend, got %d", len(found))
+	}
+	if found[0].Name() != "runit" {
+		t.Errorf("Name() = %s, want runit", found[0].Name())
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// defaultRunitServiceDir is where runit's `sv` convention expects a
+// symlink farm of active services; Debian/Void-style installs sometimes
+// use /etc/sv for the source directory instead, which the probe also
+// checks, but /etc/service is what supervise/status actually lives under.
+const defaultRunitServiceDir = "/etc/service"
+
+func init() {
+	Register("runit", func() InitSystem { return &Runit{ServiceDir: defaultRunitServiceDir} })
+	registerProbe("runit", func() bool {
+		for _, dir := range []string{"/etc/service", "/etc/sv"} {
+			if info, err := os.Stat(dir); err == nil && info.IsDir() {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Runit reads runit's binary supervise/status file directly instead of
+// shelling out to `sv status`, so a lookup is a single stat+read.
+type Runit struct {
+	ServiceDir string
+}
+
+func (r *Runit) Name() string { return "runit" }
+
+func (r *Runit) LookupService(ctx context.Context, name string) (*ProcessStatus, error) {
+	path := filepath.Join(r.ServiceDir, name, "supervise", "status")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, &ServiceError{"runit", name, ErrServiceNotFound}
+		}
+		return nil, &ServiceError{"runit", name, err}
+	}
+	ps, err := parseRunitStatus(data)
+	if err != nil {
+		return nil, &ServiceError{"runit", name, err}
+	}
+	return ps, nil
+}
+
+/*
+  parseRunitStatus decodes runit's 20-byte supervise/status format:
+
+    bytes 0-11:  TAI64N timestamp of the last state change
+    bytes 12-15: PID, little-endian, 0 if not running
+    byte  16:    paused flag
+    byte  17:    "want" state ('u' or 'd')
+    byte  18:    term flag
+    byte  19:    state (0=down, 1=run, 2=finish)
+*/
+func parseRunitStatus(data []byte) (*ProcessStatus, error) {
+	if len(data) < 20 {
+		return nil, fmt.Errorf("malformed runit status: got %d bytes, want 20", len(data))
+	}
+
+	startedAt := tai64nToTime(data[0:12])
+	pid := int(binary.LittleEndian.Uint32(data[12:16]))
+
+	status := Down
+	switch data[19] {
+	case 1:
+		status = Up
+	case 2:
+		status = Starting // the "finish" script is still running
+	}
+	if status == Up && pid == 0 {
+		status = Down
+	}
+
+	return &ProcessStatus{Pid: pid, Status: status, StartedAt: startedAt}, nil
+}
+
+// tai64Offset is 2^62, the constant TAI64 adds to the Unix epoch.
+const tai64Offset = uint64(1) << 62
+
+// tai64nToTime converts a 12-byte TAI64N timestamp to a time.Time. It
+// ignores the handful of leap seconds TAI has accumulated over Unix time
+// since we only use this for rules like `service:uptime`, where
+// sub-second drift from leap seconds doesn't matter.
+func tai64nToTime(b []byte) time.Time {
+	secs := binary.BigEndian.Uint64(b[0:8]) - tai64Offset
+	nsec := binary.BigEndian.Uint32(b[8:12])
+	return time.Unix(int64(secs), int64(nsec))
+}
+
+func (r *Runit) Restart(ctx context.Context, name string) error {
+	cmd := exec.CommandContext(ctx, "sv", "restart", filepath.Join(r.ServiceDir, name))
+	if err := cmd.Run(); err != nil {
+		return &ServiceError{"runit", name, err}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLookupServiceRunning(t *testing.T) {
+	r := &Runit{ServiceDir: "fixtures/runit"}
+	ps, err := r.LookupService(context.Background(), "myapp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ps.Pid != 4321 {
+		t.Errorf("Pid = %d, want 4321", ps.Pid)
+	}
+	if ps.Status != Up {
+		t.Errorf("Status = %s, want up", ps.Status)
+	}
+	if ps.StartedAt.Unix() != 1700000000 {
+		t.Errorf("StartedAt.Unix() = %d, want 1700000000", ps.StartedAt.Unix())
+	}
+}
+
+func TestLookupServiceNotFound(t *testing.T) {
+	r := &Runit{ServiceDir: "fixtures/runit"}
+	_, err := r.LookupService(context.Background(), "doesnotexist")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	serr, ok := err.(*ServiceError)
+	if !ok {
+		t.Fatalf("expected a *ServiceError, got %T", err)
+	}
+	if serr.Err != ErrServiceNotFound {
+		t.Errorf("Err = %v, want ErrServiceNotFound", serr.Err)
+	}
+}
+
+func TestParseRunitStatusMalformed(t *testing.T) {
+	_, err := parseRunitStatus([]byte{1, 2, 3})
+	if err == nil {
+		t.Fatal("expected an error for a too-short status file")
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+// Status is the lifecycle state of a managed process.
+type Status int
+
+const (
+	Unknown Status = iota
+	Starting
+	Up
+	Down
+)
+
+func (s Status) String() string {
+	switch s {
+	case Starting:
+		return "starting"
+	case Up:
+		return "up"
+	case Down:
+		return "down"
+	default:
+		return "unknown"
+	}
+}
+
+// ProcessStatus is a point-in-time snapshot of a managed process, as
+// returned by an InitSystem's LookupService. StartedAt and Restarts are
+// best-effort: InitSystem backends that can't report them (plain init.d)
+// leave them zero rather than erroring.
+type ProcessStatus struct {
+	Pid    int
+	Status Status
+	// StartedAt is when the process last entered its current Status,
+	// so rules can reference e.g. `service:uptime < 30s`.
+	StartedAt time.Time
+	// Restarts is how many times the init system itself has restarted
+	// this service (e.g. systemd's NRestarts), independent of any restarts
+	// inspeqtor's own RestartPolicy has triggered.
+	Restarts int
+}
+
+func (ps *ProcessStatus) String() string {
+	return fmt.Sprintf("pid %d, %s", ps.Pid, ps.Status)
+}
+
+// Uptime reports how long the process has been in its current Status. It's
+// zero if StartedAt wasn't reported by the InitSystem backend.
+func (ps *ProcessStatus) Uptime() time.Duration {
+	if ps.StartedAt.IsZero() {
+		return 0
+	}
+	return time.Since(ps.StartedAt)
+}
+
+// NewStatus returns the zero-value ProcessStatus a Service starts with
+// before it has been resolved to an init system.
+func NewStatus() *ProcessStatus {
+	return &ProcessStatus{Pid: 0, Status: Unknown}
+}
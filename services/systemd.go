@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	godbus "github.com/godbus/dbus/v5"
+)
+
+// dbusNoSuchUnit is the D-Bus error name systemd returns from
+// GetUnitProperties when the unit was never loaded, as opposed to a
+// transient D-Bus/connection failure -- only that case means the service
+// genuinely isn't managed by systemd.
+const dbusNoSuchUnit = "org.freedesktop.systemd1.NoSuchUnit"
+
+func init() {
+	Register("systemd", func() InitSystem { return &Systemd{} })
+	registerProbe("systemd", func() bool {
+		info, err := os.Stat("/run/systemd/system")
+		return err == nil && info.IsDir()
+	})
+}
+
+/*
+  Systemd talks to systemd over its D-Bus API rather than shelling out to
+  `systemctl`, so a single round-trip gets PID, ActiveState and SubState
+  together instead of parsing `systemctl show` output line by line.
+*/
+type Systemd struct{}
+
+func (s *Systemd) Name() string { return "systemd" }
+
+func (s *Systemd) LookupService(ctx context.Context, name string) (*ProcessStatus, error) {
+	conn, err := dbus.NewSystemConnectionContext(ctx)
+	if err != nil {
+		return nil, &ServiceError{"systemd", name, err}
+	}
+	defer conn.Close()
+
+	unit := name + ".service"
+	props, err := conn.GetUnitPropertiesContext(ctx, unit)
+	if err != nil {
+		var dbusErr godbus.Error
+		if errors.As(err, &dbusErr) && dbusErr.Name == dbusNoSuchUnit {
+			return nil, &ServiceError{"systemd", name, ErrServiceNotFound}
+		}
+		return nil, &ServiceError{"systemd", name, fmt.Errorf("get unit properties for %s: %w", unit, err)}
+	}
+
+	activeState, _ := props["ActiveState"].(string)
+	subState, _ := props["SubState"].(string)
+	mainPid, _ := props["MainPID"].(uint32)
+	nRestarts, _ := props["NRestarts"].(uint32)
+
+	var startedAt time.Time
+	if ts, ok := props["StateChangeTimestamp"].(uint64); ok && ts > 0 {
+		startedAt = time.UnixMicro(int64(ts))
+	}
+
+	return &ProcessStatus{
+		Pid:       int(mainPid),
+		Status:    systemdStatus(activeState, subState),
+		StartedAt: startedAt,
+		Restarts:  int(nRestarts),
+	}, nil
+}
+
+func systemdStatus(activeState, subState string) Status {
+	switch activeState {
+	case "active":
+		if subState == "running" || subState == "exited" {
+			return Up
+		}
+		return Starting
+	case "activating", "reloading":
+		return Starting
+	default:
+		return Down
+	}
+}
+
+func (s *Systemd) Restart(ctx context.Context, name string) error {
+	conn, err := dbus.NewSystemConnectionContext(ctx)
+	if err != nil {
+		return &ServiceError{"systemd", name, err}
+	}
+	defer conn.Close()
+
+	resultCh := make(chan string, 1)
+	if _, err := conn.RestartUnitContext(ctx, name+".service", "replace", resultCh); err != nil {
+		return &ServiceError{"systemd", name, err}
+	}
+
+	select {
+	case result := <-resultCh:
+		if result != "done" {
+			return &ServiceError{"systemd", name, fmt.Errorf("restart job finished with result %q", result)}
+		}
+		return nil
+	case <-ctx.Done():
+		return &ServiceError{"systemd", name, ctx.Err()}
+	}
+}
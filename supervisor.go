@@ -0,0 +1,64 @@
+package inspeqtor
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+/*
+  Supervisor owns the lifecycle of every goroutine inspeqtor spawns outside
+  of a single bounded collection cycle: CollectorPool's per-Checkable
+  workers and Service.Restart's async restart call. It mirrors the approach
+  etcd took in its auth package to stop leaking goroutines out of tests --
+  every spawned goroutine registers with a WaitGroup and watches a
+  cancelable Context, so Shutdown can block until everything it started has
+  actually exited instead of just firing a cancel and hoping.
+*/
+type Supervisor struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewSupervisor() *Supervisor {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Supervisor{ctx: ctx, cancel: cancel}
+}
+
+// Context returns the Supervisor's Context, canceled once Shutdown is
+// called. Collection cycles should derive their per-call timeouts from it.
+func (s *Supervisor) Context() context.Context {
+	return s.ctx
+}
+
+// Go runs fn in a goroutine registered with the Supervisor's WaitGroup.
+// fn is handed the Supervisor's Context and should select on ctx.Done() to
+// exit promptly once Shutdown is called.
+func (s *Supervisor) Go(fn func(ctx context.Context)) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		fn(s.ctx)
+	}()
+}
+
+// Shutdown cancels the Supervisor's Context and blocks until every
+// goroutine started via Go has returned, or timeout elapses. It reports
+// whether everything exited cleanly within timeout.
+func (s *Supervisor) Shutdown(timeout time.Duration) bool {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
@@ -0,0 +1,42 @@
+package inspeqtor
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSupervisorShutdownWaitsForGoroutines(t *testing.T) {
+	sup := NewSupervisor()
+	started := make(chan struct{})
+	finished := make(chan struct{})
+
+	sup.Go(func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		close(finished)
+	})
+
+	<-started
+	if !sup.Shutdown(time.Second) {
+		t.Fatal("Shutdown should have returned true once the goroutine observed cancellation")
+	}
+	select {
+	case <-finished:
+	default:
+		t.Fatal("Shutdown returned before the registered goroutine finished")
+	}
+}
+
+func TestSupervisorShutdownTimesOut(t *testing.T) {
+	sup := NewSupervisor()
+	release := make(chan struct{})
+	sup.Go(func(ctx context.Context) {
+		<-release
+	})
+
+	if sup.Shutdown(10 * time.Millisecond) {
+		t.Fatal("Shutdown should have timed out waiting for a goroutine that ignores cancellation")
+	}
+	close(release)
+}
@@ -1,12 +1,14 @@
 package inspeqtor
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"inspeqtor/metrics"
 	"inspeqtor/services"
 	"inspeqtor/util"
 	"syscall"
+	"time"
 )
 
 // A named thing which can checked by Inspeqtor
@@ -46,7 +48,12 @@ func NewHost() *Host {
 }
 
 func NewService(name string) *Service {
-	return &Service{&Entity{name, nil, metrics.NewProcessStore(), nil}, nil, services.NewStatus(), nil}
+	log := util.Log().With(util.F("service", name))
+	return &Service{
+		Entity:  &Entity{name, nil, metrics.NewProcessStore(), nil},
+		Process: services.NewStatus(),
+		log:     log,
+	}
 }
 
 /*
@@ -59,6 +66,22 @@ type Service struct {
 	EventHandler Action
 	Process      *services.ProcessStatus
 	Manager      services.InitSystem
+	// log always carries this service's name so every line it emits can be
+	// grepped/aggregated by service without re-stating it at each call site.
+	log   util.Logger
+	cycle int
+	// RestartPolicy bounds how aggressively Restart retries a flapping
+	// service. nil means DefaultRestartPolicy: unlimited, immediate restarts.
+	RestartPolicy  *RestartPolicy
+	restartHistory []time.Time
+	// restartsExceeded latches once RestartsExceeded has fired for the
+	// current outage, so a service stuck Down doesn't re-fire the event
+	// every collection cycle. Restart's success path resets it.
+	restartsExceeded bool
+	// Supervisor, if set, owns Restart's async goroutine so shutdown can
+	// wait for it instead of leaking it. nil falls back to a plain `go`,
+	// which is fine for tests and one-off tools.
+	Supervisor *Supervisor
 }
 
 func (s *Service) Capture(path string) error {
@@ -76,11 +99,11 @@ func (h *Host) Resolve(_ []services.InitSystem) error {
 	return nil
 }
 
-func (h *Host) Collect(completeCallback func(Checkable)) {
+func (h *Host) Collect(_ context.Context, completeCallback func(Checkable)) {
 	defer completeCallback(h)
 	err := h.Capture("/proc")
 	if err != nil {
-		util.Warn("Error collecting host metrics: %s", err.Error())
+		util.Log().Warn("Error collecting host metrics", util.F("error", err.Error()))
 	}
 }
 
@@ -95,7 +118,7 @@ type Checkable interface {
 	Resolve([]services.InitSystem) error
 	Rules() []*Rule
 	Verify() []*Event
-	Collect(func(Checkable))
+	Collect(ctx context.Context, completeCallback func(Checkable))
 }
 
 // A Service is Restartable, Host is not.
@@ -104,10 +127,11 @@ type Restartable interface {
 }
 
 /*
-  Called for each service each cycle, in parallel.  This
-  method must be thread-safe.  Since this method executes
-  in a goroutine, errors must be handled/logged here and
-  not just returned.
+  Called for each service each cycle, in parallel, via a CollectorPool.  This
+  method must be thread-safe.  Since this method executes in a goroutine,
+  errors must be handled/logged here and not just returned.  ctx is bound to
+  the pool's per-service timeout, so a hung LookupService gets cut off
+  rather than stalling the whole cycle.
 
   Each cycle we need to:
   1. verify service is Up and running.
@@ -115,17 +139,19 @@ type Restartable interface {
   3. run rules
   4. trigger any necessary actions
 */
-func (svc *Service) Collect(completeCallback func(Checkable)) {
+func (svc *Service) Collect(ctx context.Context, completeCallback func(Checkable)) {
 	defer completeCallback(svc)
+	svc.cycle++
+	log := svc.log.With(util.F("cycle", svc.cycle))
 
 	if svc.Manager == nil {
 		// Couldn't resolve it when we started up so we can't collect it.
 		return
 	}
 	if svc.Process.Status != services.Up {
-		status, err := svc.Manager.LookupService(svc.Name())
+		status, err := svc.Manager.LookupService(ctx, svc.Name())
 		if err != nil {
-			util.Warn("%s", err)
+			log.Warn(err.Error(), util.F("status", svc.Process.Status))
 		} else {
 			svc.Transition(status, func(et EventType) {
 				svc.EventHandler.Trigger(&Event{et, svc, nil})
@@ -136,14 +162,15 @@ func (svc *Service) Collect(completeCallback func(Checkable)) {
 	if svc.Process.Status == services.Up {
 		merr := svc.Capture("/proc")
 		if merr != nil {
-			err := syscall.Kill(svc.Process.Pid, syscall.Signal(0))
+			pid := svc.Process.Pid
+			err := syscall.Kill(pid, syscall.Signal(0))
 			if err != nil {
-				util.Info("Service %s with process %d does not exist: %s", svc.Name(), svc.Process.Pid, err)
-				svc.Transition(&services.ProcessStatus{0, services.Down}, func(et EventType) {
+				log.Info("Process does not exist", util.F("pid", pid), util.F("status", svc.Process.Status))
+				svc.Transition(&services.ProcessStatus{Pid: 0, Status: services.Down}, func(et EventType) {
 					svc.EventHandler.Trigger(&Event{et, svc, nil})
 				})
 			} else {
-				util.Warn("Error capturing metrics for process %d: %s", svc.Process.Pid, merr)
+				log.Warn("Error capturing metrics", util.F("pid", pid), util.F("error", merr.Error()))
 			}
 		}
 	}
@@ -163,19 +190,103 @@ func (s *Entity) Verify() []*Event {
 	return events
 }
 
+func (s *Service) restartPolicy() *RestartPolicy {
+	if s.RestartPolicy == nil {
+		return DefaultRestartPolicy
+	}
+	return s.RestartPolicy
+}
+
+// pruneRestartHistory drops restart timestamps that have fallen outside
+// the policy's Interval window, so old attempts don't count against the
+// current budget forever.
+func (s *Service) pruneRestartHistory(policy *RestartPolicy, now time.Time) {
+	if policy.Interval <= 0 {
+		return
+	}
+	cutoff := now.Add(-policy.Interval)
+	i := 0
+	for ; i < len(s.restartHistory); i++ {
+		if s.restartHistory[i].After(cutoff) {
+			break
+		}
+	}
+	s.restartHistory = s.restartHistory[i:]
+}
+
+/*
+  Restart asks the init system to restart this service, subject to its
+  RestartPolicy: once Attempts restarts have happened within Interval, a
+  RestartModeFail policy gives up and fires RestartsExceeded instead of
+  looping forever on a service that will never come back, while
+  RestartModeDelay keeps trying with the delay between attempts growing
+  exponentially (Delay * 2^n, capped at MaxDelay).
+*/
 func (s *Service) Restart() error {
+	policy := s.restartPolicy()
+	now := time.Now()
+
+	// An unbounded policy with no Interval has no budget to enforce and no
+	// window to prune, so tracking history for it would just grow
+	// restartHistory by one entry per cycle forever. Skip it and restart
+	// immediately and unconditionally, matching inspeqtor's historical
+	// (pre-RestartPolicy) behavior.
+	if policy.unbounded() && policy.Interval <= 0 {
+		s.restartsExceeded = false
+		s.doRestart(policy, 1)
+		return nil
+	}
+
+	s.pruneRestartHistory(policy, now)
+
+	attempt := len(s.restartHistory) + 1
+	if !policy.unbounded() && attempt > policy.Attempts && policy.Mode != RestartModeDelay {
+		if !s.restartsExceeded {
+			s.log.Warn("Restart budget exhausted, giving up", util.F("attempts", len(s.restartHistory)))
+			s.EventHandler.Trigger(&Event{RestartsExceeded, s, nil})
+			s.restartsExceeded = true
+		}
+		return nil
+	}
+	s.restartsExceeded = false
+
+	s.restartHistory = append(s.restartHistory, now)
+	s.doRestart(policy, attempt)
+	return nil
+}
+
+// doRestart runs the init system restart for the given attempt number,
+// backing off first if the policy calls for it, on the Supervisor if one
+// is set so shutdown can wait for it instead of leaking the goroutine.
+func (s *Service) doRestart(policy *RestartPolicy, attempt int) {
+	delay := policy.delayFor(attempt)
+
 	s.Process.Pid = 0
 	s.Process.Status = services.Starting
-	go func() {
-		util.Debug("Restarting %s", s.Name())
-		err := s.Manager.Restart(s.Name())
+	restart := func(ctx context.Context) {
+		if delay > 0 {
+			s.log.Debug("Backing off before restart", util.F("delay", delay.String()), util.F("attempt", attempt))
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				s.log.Debug("Restart canceled during backoff")
+				return
+			}
+		}
+		s.log.Debug("Restarting", util.F("pid", s.Process.Pid), util.F("status", s.Process.Status))
+		err := s.Manager.Restart(ctx, s.Name())
 		if err != nil {
-			util.Warn(err.Error())
+			s.log.Warn(err.Error())
 		} else {
-			util.DebugDebug("Restarted %s", s.Name())
+			s.log.DebugDebug("Restarted")
 		}
-	}()
-	return nil
+	}
+
+	if s.Supervisor != nil {
+		s.Supervisor.Go(restart)
+	} else {
+		go restart(context.Background())
+	}
 }
 
 /*
@@ -190,16 +301,17 @@ func (svc *Service) Resolve(mgrs []services.InitSystem) error {
 			continue
 		}
 
-		ps, err := sm.LookupService(svc.Name())
+		ps, err := sm.LookupService(context.Background(), svc.Name())
 		if err != nil {
 			serr := err.(*services.ServiceError)
 			if serr.Err == services.ErrServiceNotFound {
-				util.Debug(sm.Name() + " doesn't have " + svc.Name())
+				svc.log.Debug("Not managed by this init system", util.F("init", sm.Name()))
 				continue
 			}
+			svc.log.Error(err.Error(), util.F("init", sm.Name()))
 			return err
 		}
-		util.Info("Found %s/%s with status %s", sm.Name(), svc.Name(), ps)
+		svc.log.Info("Found service", util.F("init", sm.Name()), util.F("status", ps.Status))
 		svc.Manager = sm
 		svc.Transition(ps, func(et EventType) {
 			svc.EventHandler.Trigger(&Event{et, svc, nil})
@@ -218,6 +330,7 @@ func (s *Service) Transition(ps *services.ProcessStatus, emitter func(EventType)
 
 	switch ps.Status {
 	case services.Up:
+		s.restartsExceeded = false
 		if oldst != services.Unknown {
 			// Don't need to fire the event when first starting up and
 			// transitioning from Unknown to Up.
@@ -225,6 +338,12 @@ func (s *Service) Transition(ps *services.ProcessStatus, emitter func(EventType)
 		}
 	case services.Down:
 		emitter(ProcessDoesNotExist)
+		// Consult the RestartPolicy before restarting -- Restart itself
+		// enforces the attempt cap/backoff, so a flapping service can't
+		// crash-restart-loop tighter than the policy allows.
+		if err := s.Restart(); err != nil {
+			s.log.Warn(err.Error())
+		}
 	default:
 		// do nothing
 	}
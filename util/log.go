@@ -0,0 +1,82 @@
+package util
+
+import (
+	"fmt"
+)
+
+/*
+  Field is a structured key/value pair attached to a log line, e.g.
+  util.F("service", "nginx").
+*/
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+func F(key string, value interface{}) Field {
+	return Field{key, value}
+}
+
+/*
+  Logger is the structured logging interface used throughout Inspeqtor.
+  The default implementation is backed by go.uber.org/zap; a log.go caller
+  never needs to import zap directly.  Implementations must be safe for
+  concurrent use since Host/Service collection happens across goroutines.
+*/
+type Logger interface {
+	Debug(msg string, fields ...Field)
+	DebugDebug(msg string, fields ...Field)
+	Info(msg string, fields ...Field)
+	Warn(msg string, fields ...Field)
+	Error(msg string, fields ...Field)
+	Fatal(msg string, fields ...Field)
+
+	// With returns a child Logger which always includes the given fields,
+	// e.g. log.With(util.F("service", name)).
+	With(fields ...Field) Logger
+}
+
+// The process-wide default logger. Configure replaces it at startup once
+// inspeqtor.conf has been parsed; until then it's a console-mode zap logger.
+var std Logger = newZapLogger(Config{})
+
+// Configure rebuilds the default logger from inspeqtor.conf settings. It
+// should be called once, early in startup, before any services resolve.
+func Configure(cfg Config) {
+	std = newZapLogger(cfg)
+}
+
+// Log returns the current process-wide default Logger, for code that wants
+// to attach fields via With rather than use the legacy printf-style helpers
+// below.
+func Log() Logger {
+	return std
+}
+
+/*
+  The functions below are a Sugar-style compatibility shim: they preserve
+  the old printf-based call sites (util.Info("found %s", name)) so existing
+  code throughout the tree didn't need to be rewritten field-by-field when
+  the Logger interface was introduced. New code should prefer Log().With(...)
+  so its fields are queryable, not buried in a formatted string.
+*/
+
+func Debug(format string, args ...interface{}) {
+	std.Debug(fmt.Sprintf(format, args...))
+}
+
+func DebugDebug(format string, args ...interface{}) {
+	std.DebugDebug(fmt.Sprintf(format, args...))
+}
+
+func Info(format string, args ...interface{}) {
+	std.Info(fmt.Sprintf(format, args...))
+}
+
+func Warn(format string, args ...interface{}) {
+	std.Warn(fmt.Sprintf(format, args...))
+}
+
+func Fatal(format string, args ...interface{}) {
+	std.Fatal(fmt.Sprintf(format, args...))
+}
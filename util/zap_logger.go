@@ -0,0 +1,101 @@
+package util
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+/*
+  Config controls how the default Logger is built. It's populated from the
+  [general] section of inspeqtor.conf:
+
+    log_format production    # or "development"
+    log_level info           # debug, debugdebug, info, warn, error
+*/
+type Config struct {
+	// Production selects the JSON encoder (suitable for shipping to
+	// ELK/Loki); development selects a human-readable console encoder.
+	Production bool
+	// Level is one of debug, debugdebug, info, warn, error. Defaults to info.
+	Level string
+}
+
+// debugdebugLevel is one notch below zap's Debug, used for the extremely
+// chatty traces that util.DebugDebug historically emitted.
+const debugdebugLevel = zapcore.Level(-2)
+
+type zapLogger struct {
+	z *zap.Logger
+}
+
+func newZapLogger(cfg Config) *zapLogger {
+	var zcfg zap.Config
+	if cfg.Production {
+		zcfg = zap.NewProductionConfig()
+	} else {
+		zcfg = zap.NewDevelopmentConfig()
+	}
+	zcfg.Level = zap.NewAtomicLevelAt(levelFor(cfg.Level))
+
+	z, err := zcfg.Build(zap.AddCallerSkip(1))
+	if err != nil {
+		// Logging is infrastructure inspeqtor cannot run without; if it
+		// can't be built, fall back to a no-op rather than panicking so
+		// collection still proceeds.
+		z = zap.NewNop()
+	}
+	return &zapLogger{z}
+}
+
+func levelFor(level string) zapcore.Level {
+	switch level {
+	case "debugdebug":
+		return debugdebugLevel
+	case "debug":
+		return zapcore.DebugLevel
+	case "warn":
+		return zapcore.WarnLevel
+	case "error":
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+func toZapFields(fields []Field) []zap.Field {
+	zfs := make([]zap.Field, len(fields))
+	for i, f := range fields {
+		zfs[i] = zap.Any(f.Key, f.Value)
+	}
+	return zfs
+}
+
+func (l *zapLogger) Debug(msg string, fields ...Field) {
+	l.z.Debug(msg, toZapFields(fields)...)
+}
+
+func (l *zapLogger) DebugDebug(msg string, fields ...Field) {
+	if ce := l.z.Check(debugdebugLevel, msg); ce != nil {
+		ce.Write(toZapFields(fields)...)
+	}
+}
+
+func (l *zapLogger) Info(msg string, fields ...Field) {
+	l.z.Info(msg, toZapFields(fields)...)
+}
+
+func (l *zapLogger) Warn(msg string, fields ...Field) {
+	l.z.Warn(msg, toZapFields(fields)...)
+}
+
+func (l *zapLogger) Error(msg string, fields ...Field) {
+	l.z.Error(msg, toZapFields(fields)...)
+}
+
+func (l *zapLogger) Fatal(msg string, fields ...Field) {
+	l.z.Fatal(msg, toZapFields(fields)...)
+}
+
+func (l *zapLogger) With(fields ...Field) Logger {
+	return &zapLogger{l.z.With(toZapFields(fields)...)}
+}